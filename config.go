@@ -0,0 +1,37 @@
+package main
+
+import (
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// HostConfig describes a named machine the daemon can wake on behalf of a
+// client that only knows its name.
+type HostConfig struct {
+	Name          string `yaml:"name"`
+	MACAddress    string `yaml:"mac"`
+	InterfaceName string `yaml:"interface"`
+	Broadcast     string `yaml:"broadcast,omitempty"`
+	SecureOn      string `yaml:"secureon,omitempty" json:"-"`
+
+	// Verify, if set, is a post-wake reachability probe in scheme://target
+	// form (e.g. "tcp://192.168.1.50:22", "icmp://192.168.1.50").
+	Verify string `yaml:"verify,omitempty"`
+}
+
+// loadHostsConfig reads a YAML file listing the hosts the daemon is allowed
+// to wake.
+func loadHostsConfig(path string) ([]HostConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var hosts []HostConfig
+	if err := yaml.Unmarshal(data, &hosts); err != nil {
+		return nil, err
+	}
+
+	return hosts, nil
+}
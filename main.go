@@ -1,11 +1,16 @@
 package main
 
 import (
+	"encoding/hex"
 	"errors"
 	"flag"
 	"fmt"
 	"net"
 	"os"
+	"strings"
+	"time"
+
+	"github.com/tylerRosso/wake-on-lan/pkg/wol"
 )
 
 // Exit codes
@@ -19,190 +24,304 @@ const (
 	notAllWOLPayloadBytesSentError
 	udpConnectionError
 	wolPayloadSendingError
-)
-
-const (
-	eui48Length      int = 6
-	wakeOnLanUDPPort int = 7
-	payloadLength    int = eui48Length + (16 * eui48Length)
+	secureOnPasswordLengthError
+	hostsConfigLoadingError
+	httpServerError
+	macAddressFileReadingError
+	verifyWithMultipleMACAddressesError
 )
 
 var programFlags struct {
 	listNetworkAdapters bool
-	macAddress          net.HardwareAddr
+	macAddresses        []net.HardwareAddr
+	macAddressFile      string
 	networkAdapterName  string
+	secureOnPassword    []byte
+	raw                 bool
+	broadcast           string
+	verifyMethod        wol.VerifyMethod
+	verifyTarget        string
+	verifyTimeout       time.Duration
+	verifyInterval      time.Duration
+	retries             int
 }
 
-type wolPayload [payloadLength]byte
-
-func addressFromNetworkAdapter(networkAdapter net.Interface) *net.UDPAddr {
-	networkAdapterAddresses, err := networkAdapter.Addrs()
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "The following error occurred when fetching the addresses of the network adapter of index %d: %s\n",
-			networkAdapter.Index,
-			err.Error())
-
-		os.Exit(networkAdapterAddressesFetchingError)
+func checkParsedMACAddress() {
+	if programFlags.listNetworkAdapters {
+		return
 	}
 
-	for _, networkAdapterAddress := range networkAdapterAddresses {
-		switch ip := networkAdapterAddress.(type) {
-		case *net.IPNet:
-			ipv4 := ip.IP.To4()
-			if ipv4 == nil || ipv4.IsLoopback() {
-				continue
-			}
+	if programFlags.macAddressFile != "" {
+		macAddresses, err := readMACAddressFile(programFlags.macAddressFile)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "The following error occurred when reading the MAC address file: "+err.Error())
 
-			return &net.UDPAddr{IP: ipv4, Port: wakeOnLanUDPPort}
+			os.Exit(macAddressFileReadingError)
 		}
-	}
 
-	return nil
-}
+		programFlags.macAddresses = append(programFlags.macAddresses, macAddresses...)
+	}
 
-func addressFromNetworkAdapterName(networkAdapterName string) *net.UDPAddr {
-	networkAdapter, err := net.InterfaceByName(networkAdapterName)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "The following error occurred when fetching the network adapter named %s: %s\n",
-			networkAdapterName,
-			err.Error())
+	if len(programFlags.macAddresses) == 0 {
+		fmt.Fprintln(os.Stderr, "No MAC address informed. See program usage (-h flag).")
 
-		os.Exit(networkAdapterFetchingError)
+		os.Exit(macAddressNotInformedError)
 	}
 
-	return addressFromNetworkAdapter(*networkAdapter)
-}
+	for _, macAddress := range programFlags.macAddresses {
+		if len(macAddress) != 6 {
+			fmt.Fprintln(os.Stderr, "MAC address must be an EUI-48 identifier.")
 
-func checkParsedMACAddress() {
-	if programFlags.listNetworkAdapters {
-		return
+			os.Exit(macAddressNotEUI48Error)
+		}
 	}
 
-	if programFlags.macAddress == nil {
-		fmt.Fprintln(os.Stderr, "No MAC address informed. See program usage (-h flag).")
+	if programFlags.verifyMethod != wol.VerifyNone && len(programFlags.macAddresses) > 1 {
+		fmt.Fprintln(os.Stderr, "-verify can only be used when waking a single machine.")
 
-		os.Exit(macAddressNotInformedError)
+		os.Exit(verifyWithMultipleMACAddressesError)
 	}
 
-	if len(programFlags.macAddress) != eui48Length {
-		fmt.Fprintln(os.Stderr, "MAC address must be an EUI-48 identifier.")
+	if len(programFlags.secureOnPassword) != 0 &&
+		len(programFlags.secureOnPassword) != 4 &&
+		len(programFlags.secureOnPassword) != 6 {
+		fmt.Fprintln(os.Stderr, "SecureOn password must be 4 or 6 bytes long.")
 
-		os.Exit(macAddressNotEUI48Error)
+		os.Exit(secureOnPasswordLengthError)
 	}
 }
 
-func closeUDPConnection(udpConnection *net.UDPConn) {
-	udpConnection.Close()
-}
+func exitForWakeError(err error) {
+	fmt.Fprintln(os.Stderr, "The following error occurred when sending the Wake-on-LAN payload: "+err.Error())
 
-func createWOLPayload() wolPayload {
-	payload := wolPayload{0xff, 0xff, 0xff, 0xff, 0xff, 0xff}
+	var opErr *wol.OpError
 
-	payloadIndex := 6
+	switch {
+	case errors.Is(err, wol.ErrMACNotEUI48):
+		os.Exit(macAddressNotEUI48Error)
 
-	for i := 0; i < 16; i++ {
-		for j := 0; j < eui48Length; j++ {
-			payload[payloadIndex] = programFlags.macAddress[j]
+	case errors.Is(err, wol.ErrNoUsableAddress):
+		os.Exit(networkAdapterAddressesFetchingError)
 
-			payloadIndex++
-		}
-	}
+	case errors.Is(err, wol.ErrInterfaceNotFound):
+		os.Exit(networkAdapterFetchingError)
+
+	case errors.Is(err, wol.ErrSecureOnPasswordLength):
+		os.Exit(secureOnPasswordLengthError)
 
-	return payload
+	case errors.Is(err, wol.ErrShortWrite):
+		os.Exit(notAllWOLPayloadBytesSentError)
+
+	case errors.Is(err, wol.ErrUnsupportedVerifyMethod):
+		os.Exit(wolPayloadSendingError)
+
+	case errors.Is(err, wol.ErrInvalidBroadcastAddress):
+		os.Exit(udpConnectionError)
+
+	case errors.As(err, &opErr) && opErr.Op == "dial":
+		os.Exit(udpConnectionError)
+
+	default:
+		os.Exit(wolPayloadSendingError)
+	}
 }
 
 func listNetworkAdapters() {
-	networkAdapters, err := net.Interfaces()
+	adapters, err := wol.ListAdapters()
 	if err != nil {
 		fmt.Fprintln(os.Stderr, "The following error occurred when fetching the network adapters of the system: "+err.Error())
 
 		os.Exit(networkAdaptersFetchingError)
 	}
 
-	for _, networkAdapter := range networkAdapters {
-		networkAdapterAddress := addressFromNetworkAdapter(networkAdapter)
-
-		if networkAdapterAddress != nil {
-			fmt.Printf("Local Address: %-15s | Name: %s\n",
-				networkAdapterAddress.IP.String(),
-				networkAdapter.Name)
-		}
+	for _, adapter := range adapters {
+		fmt.Printf("Local Address: %-15s | Name: %s\n",
+			adapter.Address.IP.String(),
+			adapter.Interface.Name)
 	}
 }
 
-func openUDPConnection() *net.UDPConn {
-	var (
-		localAddress  = addressFromNetworkAdapterName(programFlags.networkAdapterName)
-		remoteAddress = &net.UDPAddr{IP: net.IP{255, 255, 255, 255}, Port: wakeOnLanUDPPort}
-	)
+func parseMACAddressFlag(macAddress string) error {
+	mac, err := net.ParseMAC(macAddress)
+	if err != nil {
+		return errors.New("could not parse MAC address")
+	}
+
+	programFlags.macAddresses = append(programFlags.macAddresses, mac)
+
+	return nil
+}
 
-	udpConnection, err := net.DialUDP("udp4", localAddress, remoteAddress)
+// readMACAddressFile reads one MAC address per line from path, skipping
+// blank lines.
+func readMACAddressFile(path string) ([]net.HardwareAddr, error) {
+	data, err := os.ReadFile(path)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "The following error occurred when trying to connect to the remote address %s from the local address %s: %s\n",
-			remoteAddress.String(),
-			localAddress.String(),
-			err.Error())
+		return nil, err
+	}
 
-		os.Exit(udpConnectionError)
+	var macAddresses []net.HardwareAddr
+
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		mac, err := net.ParseMAC(line)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse MAC address %q: %w", line, err)
+		}
+
+		macAddresses = append(macAddresses, mac)
 	}
 
-	return udpConnection
+	return macAddresses, nil
 }
 
-func parseMACAddressFlag(macAddress string) error {
-	var err error
+func parseSecureOnFlag(secureOn string) error {
+	password, err := parseSecureOnPassword(secureOn)
+	if err != nil {
+		return err
+	}
+
+	programFlags.secureOnPassword = password
+
+	return nil
+}
 
-	programFlags.macAddress, err = net.ParseMAC(macAddress)
+// parseSecureOnPassword parses a SecureOn password given in either
+// colon-hex (aa:bb:cc:dd:ee:ff) or plain hex form. An empty string is
+// parsed as no password.
+func parseSecureOnPassword(secureOn string) ([]byte, error) {
+	if secureOn == "" {
+		return nil, nil
+	}
+
+	if mac, err := net.ParseMAC(secureOn); err == nil {
+		return mac, nil
+	}
+
+	password, err := hex.DecodeString(secureOn)
 	if err != nil {
-		return errors.New("could not parse MAC address")
+		return nil, errors.New("could not parse SecureOn password")
 	}
 
+	return password, nil
+}
+
+func parseVerifyFlag(verify string) error {
+	method, target, err := parseVerifySpec(verify)
+	if err != nil {
+		return err
+	}
+
+	programFlags.verifyMethod = method
+	programFlags.verifyTarget = target
+
 	return nil
 }
 
+// parseVerifySpec parses a post-wake verification spec of the form
+// "scheme://target" (e.g. "tcp://host:22", "icmp://host", "arp://1.2.3.4")
+// into a VerifyMethod and its target.
+func parseVerifySpec(spec string) (wol.VerifyMethod, string, error) {
+	scheme, target, ok := strings.Cut(spec, "://")
+	if !ok {
+		return wol.VerifyNone, "", errors.New("verify target must be in scheme://target form (tcp, icmp, or arp)")
+	}
+
+	switch scheme {
+	case "tcp":
+		return wol.VerifyTCP, target, nil
+	case "icmp":
+		return wol.VerifyICMP, target, nil
+	case "arp":
+		return wol.VerifyARP, target, nil
+	default:
+		return wol.VerifyNone, "", fmt.Errorf("unknown verify scheme %q, expected tcp, icmp, or arp", scheme)
+	}
+}
+
 func parseProgramFlags() {
 	flag.Parse()
 
 	checkParsedMACAddress()
 }
 
-func sendWOLPayload(udpConnection *net.UDPConn, payload wolPayload) {
-	fmt.Printf("Sending Wake-on-LAN payload to the remote address %s from the local address %s.\n",
-		udpConnection.RemoteAddr().String(),
-		udpConnection.LocalAddr().String())
+func wakeRemoteComputer() {
+	client := wol.NewClient(programFlags.networkAdapterName)
+	client.SecureOn = programFlags.secureOnPassword
+	client.Broadcast = programFlags.broadcast
 
-	bytesWritten, err := udpConnection.Write(payload[:])
-	if err != nil {
-		fmt.Fprintln(os.Stderr, "The following error occurred when sending the Wake-on-LAN payload: "+err.Error())
+	if programFlags.raw {
+		client.Mode = wol.ModeLayerTwo
+	}
 
-		os.Exit(wolPayloadSendingError)
+	if programFlags.verifyMethod == wol.VerifyNone {
+		fmt.Printf("Sending Wake-on-LAN payload to %d machine(s) from the network adapter named %s.\n",
+			len(programFlags.macAddresses),
+			programFlags.networkAdapterName)
 
-	} else if bytesWritten == payloadLength {
-		fmt.Printf("Wake-on-LAN payload sent.")
+		if err := client.WakeMany(programFlags.macAddresses); err != nil {
+			exitForWakeError(err)
 
-	} else {
-		fmt.Fprintf(os.Stderr, "Not all %d bytes of the payload were sent to the remote address.\n", payloadLength)
+			return
+		}
 
-		os.Exit(notAllWOLPayloadBytesSentError)
+		fmt.Printf("Wake-on-LAN payload sent.")
+
+		return
 	}
-}
 
-func wakeRemoteComputer() {
-	udpConnection := openUDPConnection()
+	fmt.Printf("Sending Wake-on-LAN payload to %s from the network adapter named %s.\n",
+		programFlags.macAddresses[0].String(),
+		programFlags.networkAdapterName)
+
+	result, err := client.WakeAndVerify(programFlags.macAddresses[0], wol.VerifyOptions{
+		Method:   programFlags.verifyMethod,
+		Target:   programFlags.verifyTarget,
+		Timeout:  programFlags.verifyTimeout,
+		Interval: programFlags.verifyInterval,
+		Retries:  programFlags.retries,
+	})
+	if err != nil {
+		exitForWakeError(err)
 
-	sendWOLPayload(udpConnection, createWOLPayload())
+		return
+	}
 
-	defer closeUDPConnection(udpConnection)
+	if result.Reachable {
+		fmt.Printf("Wake-on-LAN payload sent; %s became reachable after %d attempt(s) (%s).",
+			programFlags.verifyTarget, result.Attempts, result.FirstResponse)
+	} else {
+		fmt.Printf("Wake-on-LAN payload sent; %s did not become reachable after %d attempt(s).",
+			programFlags.verifyTarget, result.Attempts)
+	}
 }
 
 func init() {
 	flag.BoolVar(&programFlags.listNetworkAdapters, "list-network-adapters", false, "lists system network adapters")
 	flag.StringVar(&programFlags.networkAdapterName, "network-adapter-name", "", "`name` of the network adapter to be used")
-
-	flag.Func("mac-address", "`mac address` of the computer to be awaken", parseMACAddressFlag)
+	flag.BoolVar(&programFlags.raw, "raw", false, "send the magic packet as a raw Ethernet frame instead of a UDP broadcast")
+	flag.StringVar(&programFlags.broadcast, "broadcast", "", "`address` the magic packet is sent to: the limited broadcast (default), \"auto\" for the network adapter's directed broadcast, an explicit directed broadcast, or an IPv6 address")
+	flag.StringVar(&programFlags.macAddressFile, "mac-file", "", "`path` to a file with one MAC address per line, to wake alongside any -mac-address flags")
+	flag.DurationVar(&programFlags.verifyTimeout, "verify-timeout", 5*time.Second, "how long to wait for the -verify probe to succeed before giving up or retrying")
+	flag.DurationVar(&programFlags.verifyInterval, "verify-interval", time.Second, "delay between -verify probe attempts")
+	flag.IntVar(&programFlags.retries, "retries", 0, "number of times to retransmit the magic packet if -verify keeps failing")
+
+	flag.Func("mac-address", "`mac address` of the computer to be awaken; may be repeated to wake several machines", parseMACAddressFlag)
+	flag.Func("secureon", "`password` (colon-hex or plain hex, 4 or 6 bytes) of the computer's SecureOn feature", parseSecureOnFlag)
+	flag.Func("verify", "post-wake reachability probe: `tcp://host:port`, `icmp://host`, or `arp://ip`", parseVerifyFlag)
 }
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "serve" {
+		runServeCommand(os.Args[2:])
+
+		return
+	}
+
 	parseProgramFlags()
 
 	if programFlags.listNetworkAdapters {
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestReadMACAddressFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "hosts.txt")
+	contents := "aa:bb:cc:dd:ee:ff\n\n11:22:33:44:55:66\n"
+	if err := os.WriteFile(path, []byte(contents), 0o644); err != nil {
+		t.Fatalf("failed to write test file: %s", err)
+	}
+
+	macAddresses, err := readMACAddressFile(path)
+	if err != nil {
+		t.Fatalf("readMACAddressFile returned an unexpected error: %s", err)
+	}
+
+	if len(macAddresses) != 2 {
+		t.Fatalf("expected 2 MAC addresses, got %d", len(macAddresses))
+	}
+
+	if !bytes.Equal(macAddresses[0], []byte{0xaa, 0xbb, 0xcc, 0xdd, 0xee, 0xff}) {
+		t.Fatalf("unexpected first MAC address: %s", macAddresses[0])
+	}
+
+	if !bytes.Equal(macAddresses[1], []byte{0x11, 0x22, 0x33, 0x44, 0x55, 0x66}) {
+		t.Fatalf("unexpected second MAC address: %s", macAddresses[1])
+	}
+}
+
+func TestReadMACAddressFileRejectsInvalidAddress(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "hosts.txt")
+	if err := os.WriteFile(path, []byte("not-a-mac\n"), 0o644); err != nil {
+		t.Fatalf("failed to write test file: %s", err)
+	}
+
+	if _, err := readMACAddressFile(path); err == nil {
+		t.Fatal("expected an error for an invalid MAC address, got nil")
+	}
+}
@@ -0,0 +1,144 @@
+package wol
+
+import (
+	"fmt"
+	"net"
+)
+
+const wakeOnLanUDPPort int = 7
+
+// AdapterAddress pairs a network interface with the local UDP address
+// Wake-on-LAN payloads would be sent from on that interface.
+type AdapterAddress struct {
+	Interface net.Interface
+	Address   *net.UDPAddr
+}
+
+// ipv4NetForInterface returns the interface's usable IPv4 address and
+// subnet mask, or nil if it has none.
+func ipv4NetForInterface(iface net.Interface) (*net.IPNet, error) {
+	addrs, err := iface.Addrs()
+	if err != nil {
+		return nil, &OpError{Op: "list addresses", Net: iface.Name, Err: err}
+	}
+
+	for _, addr := range addrs {
+		ipNet, ok := addr.(*net.IPNet)
+		if !ok {
+			continue
+		}
+
+		ipv4 := ipNet.IP.To4()
+		if ipv4 == nil || ipv4.IsLoopback() {
+			continue
+		}
+
+		return &net.IPNet{IP: ipv4, Mask: ipNet.Mask}, nil
+	}
+
+	return nil, nil
+}
+
+// udpAddressForInterface returns the UDP address to send Wake-on-LAN
+// payloads from the given interface, or nil if the interface has no usable
+// IPv4 address.
+func udpAddressForInterface(iface net.Interface) (*net.UDPAddr, error) {
+	ipNet, err := ipv4NetForInterface(iface)
+	if err != nil {
+		return nil, err
+	}
+
+	if ipNet == nil {
+		return nil, nil
+	}
+
+	return &net.UDPAddr{IP: ipNet.IP, Port: wakeOnLanUDPPort}, nil
+}
+
+// udpAddressForInterfaceName resolves name to a network interface and
+// returns the UDP address Wake-on-LAN payloads would be sent from.
+func udpAddressForInterfaceName(name string) (*net.UDPAddr, error) {
+	iface, err := net.InterfaceByName(name)
+	if err != nil {
+		return nil, &OpError{Op: "lookup interface", Net: name, Err: fmt.Errorf("%w: %v", ErrInterfaceNotFound, err)}
+	}
+
+	address, err := udpAddressForInterface(*iface)
+	if err != nil {
+		return nil, err
+	}
+
+	if address == nil {
+		return nil, &OpError{Op: "lookup interface", Net: name, Err: ErrNoUsableAddress}
+	}
+
+	return address, nil
+}
+
+// ipv4ForInterfaceName resolves name to a network interface and returns its
+// usable IPv4 address.
+func ipv4ForInterfaceName(name string) (net.IP, error) {
+	address, err := udpAddressForInterfaceName(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return address.IP, nil
+}
+
+// directedBroadcastForInterfaceName resolves name to a network interface and
+// derives the directed IPv4 broadcast address of its attached subnet from
+// its address and mask (e.g. 192.168.1.23/24 -> 192.168.1.255).
+func directedBroadcastForInterfaceName(name string) (net.IP, error) {
+	iface, err := net.InterfaceByName(name)
+	if err != nil {
+		return nil, &OpError{Op: "lookup interface", Net: name, Err: fmt.Errorf("%w: %v", ErrInterfaceNotFound, err)}
+	}
+
+	ipNet, err := ipv4NetForInterface(*iface)
+	if err != nil {
+		return nil, err
+	}
+
+	if ipNet == nil {
+		return nil, &OpError{Op: "lookup interface", Net: name, Err: ErrNoUsableAddress}
+	}
+
+	return broadcastForIPNet(ipNet), nil
+}
+
+// broadcastForIPNet derives the directed IPv4 broadcast address of ipNet
+// from its address and mask (e.g. 192.168.1.23/24 -> 192.168.1.255).
+func broadcastForIPNet(ipNet *net.IPNet) net.IP {
+	broadcast := make(net.IP, len(ipNet.IP))
+	for i := range ipNet.IP {
+		broadcast[i] = ipNet.IP[i] | ^ipNet.Mask[i]
+	}
+
+	return broadcast
+}
+
+// ListAdapters returns the system's network interfaces paired with the
+// local UDP address Wake-on-LAN payloads would be sent from, skipping
+// adapters with no usable IPv4 address.
+func ListAdapters() ([]AdapterAddress, error) {
+	ifaces, err := net.Interfaces()
+	if err != nil {
+		return nil, &OpError{Op: "list interfaces", Err: err}
+	}
+
+	var adapters []AdapterAddress
+
+	for _, iface := range ifaces {
+		address, err := udpAddressForInterface(iface)
+		if err != nil {
+			return nil, err
+		}
+
+		if address != nil {
+			adapters = append(adapters, AdapterAddress{Interface: iface, Address: address})
+		}
+	}
+
+	return adapters, nil
+}
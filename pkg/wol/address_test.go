@@ -0,0 +1,28 @@
+package wol
+
+import (
+	"net"
+	"testing"
+)
+
+func TestBroadcastForIPNet(t *testing.T) {
+	tests := []struct {
+		ip   string
+		bits int
+		want string
+	}{
+		{"192.168.1.23", 24, "192.168.1.255"},
+		{"192.168.1.23", 25, "192.168.1.127"},
+		{"192.168.1.23", 16, "192.168.255.255"},
+	}
+
+	for _, test := range tests {
+		ip := net.ParseIP(test.ip).To4()
+		mask := net.CIDRMask(test.bits, 32)
+
+		got := broadcastForIPNet(&net.IPNet{IP: ip, Mask: mask})
+		if got.String() != test.want {
+			t.Fatalf("broadcastForIPNet(%s/%d) = %s, want %s", test.ip, test.bits, got, test.want)
+		}
+	}
+}
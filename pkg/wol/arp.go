@@ -0,0 +1,59 @@
+package wol
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net"
+	"time"
+)
+
+const etherTypeARP uint16 = 0x0806
+
+const (
+	arpOperationRequest uint16 = 1
+	arpOperationReply   uint16 = 2
+)
+
+// arpRequest builds a raw ARP request packet (the Ethernet header is built
+// separately) asking who has targetIP, sent on behalf of senderMAC/senderIP.
+func arpRequest(senderMAC net.HardwareAddr, senderIP, targetIP net.IP) []byte {
+	pkt := make([]byte, 28)
+
+	binary.BigEndian.PutUint16(pkt[0:2], 1)      // hardware type: Ethernet
+	binary.BigEndian.PutUint16(pkt[2:4], 0x0800) // protocol type: IPv4
+	pkt[4] = byte(eui48Length)                   // hardware address length
+	pkt[5] = 4                                   // protocol address length
+	binary.BigEndian.PutUint16(pkt[6:8], arpOperationRequest)
+	copy(pkt[8:14], senderMAC)
+	copy(pkt[14:18], senderIP.To4())
+	// target hardware address (pkt[18:24]) is left zeroed: unknown.
+	copy(pkt[24:28], targetIP.To4())
+
+	return pkt
+}
+
+// arpReplySenderIP parses a raw ARP packet (the Ethernet header must
+// already be stripped) and, if it is a reply, returns the sender's IPv4
+// address.
+func arpReplySenderIP(pkt []byte) (net.IP, bool) {
+	if len(pkt) < 28 {
+		return nil, false
+	}
+
+	if binary.BigEndian.Uint16(pkt[6:8]) != arpOperationReply {
+		return nil, false
+	}
+
+	return net.IP(pkt[14:18]), true
+}
+
+// probeARP sends an ARP request for target (an IPv4 address) on c's
+// interface and reports whether a reply arrived before timeout.
+func (c *Client) probeARP(target string, timeout time.Duration) (bool, error) {
+	targetIP := net.ParseIP(target)
+	if targetIP == nil || targetIP.To4() == nil {
+		return false, fmt.Errorf("invalid ARP target address %q", target)
+	}
+
+	return probeARPOnInterface(c.InterfaceName, targetIP.To4(), timeout)
+}
@@ -0,0 +1,76 @@
+package wol
+
+import (
+	"fmt"
+	"net"
+	"syscall"
+	"time"
+)
+
+// probeARPOnInterface sends an ARP request for targetIP on interfaceName
+// using an AF_PACKET/SOCK_RAW socket and reports whether a reply arrived
+// before timeout.
+func probeARPOnInterface(interfaceName string, targetIP net.IP, timeout time.Duration) (bool, error) {
+	iface, err := net.InterfaceByName(interfaceName)
+	if err != nil {
+		return false, &OpError{Op: "lookup interface", Net: interfaceName, Err: fmt.Errorf("%w: %v", ErrInterfaceNotFound, err)}
+	}
+
+	senderIP, err := ipv4ForInterfaceName(interfaceName)
+	if err != nil {
+		return false, err
+	}
+
+	fd, err := syscall.Socket(syscall.AF_PACKET, syscall.SOCK_RAW, int(htons(etherTypeARP)))
+	if err != nil {
+		return false, &OpError{Op: "open raw socket", Net: "packet", Err: err}
+	}
+	defer syscall.Close(fd)
+
+	addr := syscall.SockaddrLinklayer{
+		Protocol: htons(etherTypeARP),
+		Ifindex:  iface.Index,
+		Halen:    uint8(eui48Length),
+	}
+	copy(addr.Addr[:], broadcastMAC)
+
+	frame := ethernetFrame(iface.HardwareAddr, etherTypeARP, arpRequest(iface.HardwareAddr, senderIP, targetIP))
+
+	if err := syscall.Sendto(fd, frame, 0, &addr); err != nil {
+		return false, &OpError{Op: "write", Net: "packet", Err: err}
+	}
+
+	deadline := time.Now().Add(timeout)
+	buf := make([]byte, 1500)
+
+	for {
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			return false, nil
+		}
+
+		rcvTimeout := syscall.NsecToTimeval(remaining.Nanoseconds())
+		if err := syscall.SetsockoptTimeval(fd, syscall.SOL_SOCKET, syscall.SO_RCVTIMEO, &rcvTimeout); err != nil {
+			return false, &OpError{Op: "set read timeout", Net: "packet", Err: err}
+		}
+
+		n, _, err := syscall.Recvfrom(fd, buf, 0)
+		if err != nil {
+			return false, nil
+		}
+
+		const ethernetHeaderLength = 2*eui48Length + 2
+		if n < ethernetHeaderLength {
+			continue
+		}
+
+		senderIPReply, ok := arpReplySenderIP(buf[ethernetHeaderLength:n])
+		if !ok {
+			continue
+		}
+
+		if senderIPReply.Equal(targetIP) {
+			return true, nil
+		}
+	}
+}
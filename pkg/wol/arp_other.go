@@ -0,0 +1,15 @@
+//go:build !linux
+
+package wol
+
+import (
+	"net"
+	"time"
+)
+
+// probeARPOnInterface is not implemented on this platform: ARP probing is
+// only implemented with the Linux AF_PACKET socket, and always returns
+// ErrUnsupportedVerifyMethod here.
+func probeARPOnInterface(interfaceName string, targetIP net.IP, timeout time.Duration) (bool, error) {
+	return false, ErrUnsupportedVerifyMethod
+}
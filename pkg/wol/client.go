@@ -0,0 +1,196 @@
+package wol
+
+import (
+	"errors"
+	"fmt"
+	"net"
+)
+
+// ErrInvalidBroadcastAddress is returned when a Client's Broadcast field
+// cannot be parsed as an IP address.
+var ErrInvalidBroadcastAddress = errors.New("invalid broadcast address")
+
+// Mode selects the transport a Client uses to send a magic packet.
+type Mode int
+
+const (
+	// ModeUDP sends the magic packet as a UDP broadcast (over IPv6 when
+	// Broadcast is an IPv6 address, otherwise over IPv4). This is the
+	// default and works as long as the target interface has an IP address
+	// configured and the sender is on the same broadcast domain (or a
+	// directed broadcast reaches it).
+	ModeUDP Mode = iota
+
+	// ModeLayerTwo sends the magic packet as a raw Ethernet II frame,
+	// bypassing the kernel IP stack entirely. This reaches targets that
+	// have no IP configured yet or that only share an L2 segment with the
+	// sender.
+	ModeLayerTwo
+)
+
+// Client sends Wake-on-LAN magic packets from a chosen network interface.
+type Client struct {
+	// InterfaceName is the name of the network adapter to send from.
+	InterfaceName string
+
+	// SecureOn is an optional 4- or 6-byte SecureOn password appended to
+	// the magic packet, required by some NICs before they will wake.
+	SecureOn []byte
+
+	// Mode selects the transport used to send the magic packet. The zero
+	// value is ModeUDP.
+	Mode Mode
+
+	// Broadcast is the remote address ModeUDP sends to. It may be:
+	//   - "" (the default): the limited IPv4 broadcast, 255.255.255.255.
+	//   - "auto": the directed broadcast of InterfaceName's IPv4 subnet,
+	//     derived from its address and mask.
+	//   - an explicit IPv4 directed broadcast (e.g. "192.168.1.255"), which
+	//     routers will forward to a remote subnet.
+	//   - an IPv6 address (e.g. "ff02::1", the all-nodes link-local
+	//     address), for IPv6 WoL variants.
+	// It is ignored in ModeLayerTwo.
+	Broadcast string
+}
+
+// NewClient returns a Client that sends Wake-on-LAN packets from the named
+// network interface.
+func NewClient(interfaceName string) *Client {
+	return &Client{InterfaceName: interfaceName}
+}
+
+// Wake sends a Wake-on-LAN magic packet to target using c.Mode.
+func (c *Client) Wake(target net.HardwareAddr) error {
+	payload, err := NewMagicPacket(target, c.SecureOn)
+	if err != nil {
+		return err
+	}
+
+	if c.Mode == ModeLayerTwo {
+		raw, err := openRawConn(c.InterfaceName)
+		if err != nil {
+			return err
+		}
+		defer raw.Close()
+
+		return raw.sendEthernetFrame(payload)
+	}
+
+	conn, err := c.openUDPConnection()
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	return sendPayload(conn, payload, target)
+}
+
+// WakeMany sends a Wake-on-LAN magic packet to each target in turn, reusing
+// a single connection (or raw socket, in ModeLayerTwo) across all of them.
+// It stops and returns the first error encountered.
+func (c *Client) WakeMany(targets []net.HardwareAddr) error {
+	if c.Mode == ModeLayerTwo {
+		raw, err := openRawConn(c.InterfaceName)
+		if err != nil {
+			return err
+		}
+		defer raw.Close()
+
+		for _, target := range targets {
+			payload, err := NewMagicPacket(target, c.SecureOn)
+			if err != nil {
+				return err
+			}
+
+			if err := raw.sendEthernetFrame(payload); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	conn, err := c.openUDPConnection()
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	for _, target := range targets {
+		payload, err := NewMagicPacket(target, c.SecureOn)
+		if err != nil {
+			return err
+		}
+
+		if err := sendPayload(conn, payload, target); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *Client) openUDPConnection() (*net.UDPConn, error) {
+	remoteIP, err := c.resolveBroadcastAddress()
+	if err != nil {
+		return nil, err
+	}
+
+	if ipv4 := remoteIP.To4(); ipv4 != nil {
+		localAddress, err := udpAddressForInterfaceName(c.InterfaceName)
+		if err != nil {
+			return nil, err
+		}
+
+		remoteAddress := &net.UDPAddr{IP: ipv4, Port: wakeOnLanUDPPort}
+
+		conn, err := net.DialUDP("udp4", localAddress, remoteAddress)
+		if err != nil {
+			return nil, &OpError{Op: "dial", Net: "udp4", Err: err}
+		}
+
+		return conn, nil
+	}
+
+	remoteAddress := &net.UDPAddr{IP: remoteIP, Port: wakeOnLanUDPPort, Zone: c.InterfaceName}
+
+	conn, err := net.DialUDP("udp6", nil, remoteAddress)
+	if err != nil {
+		return nil, &OpError{Op: "dial", Net: "udp6", Err: err}
+	}
+
+	return conn, nil
+}
+
+// resolveBroadcastAddress interprets c.Broadcast as documented on the
+// Broadcast field.
+func (c *Client) resolveBroadcastAddress() (net.IP, error) {
+	switch c.Broadcast {
+	case "":
+		return net.IPv4(255, 255, 255, 255), nil
+
+	case "auto":
+		return directedBroadcastForInterfaceName(c.InterfaceName)
+
+	default:
+		ip := net.ParseIP(c.Broadcast)
+		if ip == nil {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidBroadcastAddress, c.Broadcast)
+		}
+
+		return ip, nil
+	}
+}
+
+func sendPayload(conn *net.UDPConn, payload []byte, target net.HardwareAddr) error {
+	bytesWritten, err := conn.Write(payload)
+	if err != nil {
+		return &OpError{Op: "write", Net: conn.RemoteAddr().Network(), Target: target, Err: err}
+	}
+
+	if bytesWritten != len(payload) {
+		return &OpError{Op: "write", Net: conn.RemoteAddr().Network(), Target: target, Err: ErrShortWrite}
+	}
+
+	return nil
+}
@@ -0,0 +1,43 @@
+package wol
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestResolveBroadcastAddressDefaultsToLimitedBroadcast(t *testing.T) {
+	c := &Client{}
+
+	ip, err := c.resolveBroadcastAddress()
+	if err != nil {
+		t.Fatalf("resolveBroadcastAddress returned an unexpected error: %s", err)
+	}
+
+	if ip.String() != "255.255.255.255" {
+		t.Fatalf("expected the limited broadcast address, got %s", ip)
+	}
+}
+
+func TestResolveBroadcastAddressParsesExplicitAddresses(t *testing.T) {
+	for _, address := range []string{"192.168.1.255", "ff02::1"} {
+		c := &Client{Broadcast: address}
+
+		ip, err := c.resolveBroadcastAddress()
+		if err != nil {
+			t.Fatalf("resolveBroadcastAddress(%q) returned an unexpected error: %s", address, err)
+		}
+
+		if ip.String() != address {
+			t.Fatalf("expected %s, got %s", address, ip)
+		}
+	}
+}
+
+func TestResolveBroadcastAddressRejectsGarbage(t *testing.T) {
+	c := &Client{Broadcast: "not-an-address"}
+
+	_, err := c.resolveBroadcastAddress()
+	if !errors.Is(err, ErrInvalidBroadcastAddress) {
+		t.Fatalf("expected ErrInvalidBroadcastAddress, got %v", err)
+	}
+}
@@ -0,0 +1,3 @@
+// Package wol implements the Wake-on-LAN magic packet protocol, so other Go
+// programs can wake remote machines without shelling out to a CLI.
+package wol
@@ -0,0 +1,60 @@
+package wol
+
+import (
+	"errors"
+	"net"
+)
+
+// Sentinel errors returned (possibly wrapped in an *OpError) by this package.
+var (
+	ErrMACNotEUI48            = errors.New("MAC address must be an EUI-48 identifier")
+	ErrNoMACAddress           = errors.New("no MAC address informed")
+	ErrInterfaceNotFound      = errors.New("network interface not found")
+	ErrNoUsableAddress        = errors.New("network interface has no usable IPv4 address")
+	ErrShortWrite             = errors.New("not all bytes of the Wake-on-LAN payload were sent")
+	ErrSecureOnPasswordLength = errors.New("SecureOn password must be 4 or 6 bytes long")
+)
+
+// OpError records an error encountered during a Wake-on-LAN operation. It is
+// modeled on net.OpError: Source and Target, when known, are respectively the
+// MAC address of the network adapter used to send the packet and the MAC
+// address of the machine being woken.
+type OpError struct {
+	Op     string
+	Net    string
+	Source net.HardwareAddr
+	Target net.HardwareAddr
+	Err    error
+}
+
+func (e *OpError) Error() string {
+	if e == nil {
+		return "<nil>"
+	}
+
+	s := e.Op
+	if e.Net != "" {
+		s += " " + e.Net
+	}
+
+	if e.Source != nil || e.Target != nil {
+		s += " "
+		if e.Source != nil {
+			s += e.Source.String()
+		}
+		s += "->"
+		if e.Target != nil {
+			s += e.Target.String()
+		}
+	}
+
+	if e.Err != nil {
+		s += ": " + e.Err.Error()
+	}
+
+	return s
+}
+
+// Unwrap allows errors.Is and errors.As to see through an *OpError to the
+// sentinel or underlying error it wraps.
+func (e *OpError) Unwrap() error { return e.Err }
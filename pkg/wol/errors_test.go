@@ -0,0 +1,27 @@
+package wol
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestOpErrorUnwrap(t *testing.T) {
+	opErr := &OpError{Op: "write", Err: ErrShortWrite}
+
+	if !errors.Is(opErr, ErrShortWrite) {
+		t.Fatalf("expected errors.Is to see through OpError to ErrShortWrite")
+	}
+}
+
+func TestOpErrorAs(t *testing.T) {
+	var err error = &OpError{Op: "dial", Net: "udp4", Err: errors.New("boom")}
+
+	var opErr *OpError
+	if !errors.As(err, &opErr) {
+		t.Fatalf("expected errors.As to match *OpError")
+	}
+
+	if opErr.Op != "dial" {
+		t.Fatalf("expected Op %q, got %q", "dial", opErr.Op)
+	}
+}
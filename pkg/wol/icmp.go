@@ -0,0 +1,91 @@
+package wol
+
+import (
+	"encoding/binary"
+	"net"
+	"os"
+	"time"
+)
+
+const (
+	icmpTypeEchoRequest byte = 8
+	icmpTypeEchoReply   byte = 0
+)
+
+// probeICMP sends an ICMP echo request to target and reports whether an
+// echo reply arrived before timeout. It requires permission to open a raw
+// IP socket (CAP_NET_RAW on Linux).
+func probeICMP(target string, timeout time.Duration) (bool, error) {
+	conn, err := net.DialTimeout("ip4:icmp", target, timeout)
+	if err != nil {
+		return false, nil
+	}
+	defer conn.Close()
+
+	request := newICMPEchoRequest(uint16(os.Getpid()), 1)
+	if _, err := conn.Write(request); err != nil {
+		return false, nil
+	}
+
+	if err := conn.SetReadDeadline(time.Now().Add(timeout)); err != nil {
+		return false, err
+	}
+
+	reply := make([]byte, 1500)
+
+	n, err := conn.Read(reply)
+	if err != nil {
+		return false, nil
+	}
+
+	return isEchoReply(reply[:n]), nil
+}
+
+// isEchoReply reports whether reply, as read off an "ip4:icmp" socket, is an
+// ICMP echo reply. Such sockets deliver the IPv4 header along with the ICMP
+// message, so the reply's type byte is past the (variable-length) header
+// rather than at offset 0.
+func isEchoReply(reply []byte) bool {
+	if len(reply) < 1 {
+		return false
+	}
+
+	ihl := int(reply[0]&0x0f) * 4
+	if len(reply) < ihl+1 {
+		return false
+	}
+
+	return reply[ihl] == icmpTypeEchoReply
+}
+
+// newICMPEchoRequest builds a minimal ICMP echo request with no payload.
+func newICMPEchoRequest(identifier, sequence uint16) []byte {
+	msg := make([]byte, 8)
+	msg[0] = icmpTypeEchoRequest
+	msg[1] = 0
+	binary.BigEndian.PutUint16(msg[4:6], identifier)
+	binary.BigEndian.PutUint16(msg[6:8], sequence)
+	binary.BigEndian.PutUint16(msg[2:4], icmpChecksum(msg))
+
+	return msg
+}
+
+// icmpChecksum computes the ICMP checksum (RFC 792) of data, whose
+// checksum field must be zeroed beforehand.
+func icmpChecksum(data []byte) uint16 {
+	var sum uint32
+
+	for i := 0; i+1 < len(data); i += 2 {
+		sum += uint32(data[i])<<8 | uint32(data[i+1])
+	}
+
+	if len(data)%2 == 1 {
+		sum += uint32(data[len(data)-1]) << 8
+	}
+
+	for sum>>16 > 0 {
+		sum = (sum & 0xffff) + (sum >> 16)
+	}
+
+	return ^uint16(sum)
+}
@@ -0,0 +1,42 @@
+package wol
+
+import "testing"
+
+func TestICMPChecksum(t *testing.T) {
+	msg := newICMPEchoRequest(1, 1)
+
+	checksum := icmpChecksum(msg)
+	if checksum != 0 {
+		t.Fatalf("checksum of a message already carrying its own correct checksum should be 0, got %d", checksum)
+	}
+}
+
+func TestIsEchoReplySkipsIPHeader(t *testing.T) {
+	// A minimal 20-byte IPv4 header (IHL=5) followed by an ICMP echo reply
+	// (type 0), as delivered by an "ip4:icmp" socket.
+	reply := append([]byte{0x45, 0, 0, 0x1c, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}, 0, 0, 0xff, 0xff)
+
+	if !isEchoReply(reply) {
+		t.Fatalf("expected an echo reply with a 20-byte IP header to be recognized")
+	}
+}
+
+func TestIsEchoReplyRejectsOtherTypes(t *testing.T) {
+	// Same IP header, but an ICMP type other than echo reply (e.g. 8, echo
+	// request looped back).
+	reply := append([]byte{0x45, 0, 0, 0x1c, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}, icmpTypeEchoRequest, 0, 0xff, 0xff)
+
+	if isEchoReply(reply) {
+		t.Fatalf("expected an echo request to not be recognized as an echo reply")
+	}
+}
+
+func TestIsEchoReplyRejectsShortPackets(t *testing.T) {
+	if isEchoReply(nil) {
+		t.Fatalf("expected an empty reply to not be recognized as an echo reply")
+	}
+
+	if isEchoReply([]byte{0x45}) {
+		t.Fatalf("expected a reply shorter than its declared IP header to not be recognized as an echo reply")
+	}
+}
@@ -0,0 +1,49 @@
+package wol
+
+import "net"
+
+const eui48Length int = 6
+
+// Valid lengths, in bytes, of a SecureOn password as defined by AMD's Magic
+// Packet specification: either a 4-byte IPv4-style password or a 6-byte
+// MAC-style password.
+const (
+	secureOnPasswordLengthIPv4 int = 4
+	secureOnPasswordLengthMAC  int = 6
+)
+
+// MagicPacket is the Wake-on-LAN payload sent to wake a target machine: 6
+// bytes of 0xff, followed by the target's MAC address repeated 16 times,
+// and an optional 4- or 6-byte SecureOn password.
+type MagicPacket []byte
+
+// NewMagicPacket builds the magic packet that wakes the machine identified
+// by target. secureOn, if non-empty, is appended after the MAC repetitions
+// as the machine's SecureOn password; it must be 4 or 6 bytes long, or
+// ErrSecureOnPasswordLength is returned. NewMagicPacket returns
+// ErrMACNotEUI48 if target is not a 6-byte EUI-48 address.
+func NewMagicPacket(target net.HardwareAddr, secureOn []byte) (MagicPacket, error) {
+	if len(target) != eui48Length {
+		return nil, &OpError{Op: "build magic packet", Target: target, Err: ErrMACNotEUI48}
+	}
+
+	if len(secureOn) != 0 && len(secureOn) != secureOnPasswordLengthIPv4 && len(secureOn) != secureOnPasswordLengthMAC {
+		return nil, &OpError{Op: "build magic packet", Target: target, Err: ErrSecureOnPasswordLength}
+	}
+
+	payload := make(MagicPacket, eui48Length+16*eui48Length+len(secureOn))
+
+	for i := 0; i < eui48Length; i++ {
+		payload[i] = 0xff
+	}
+
+	payloadIndex := eui48Length
+
+	for i := 0; i < 16; i++ {
+		payloadIndex += copy(payload[payloadIndex:], target)
+	}
+
+	copy(payload[payloadIndex:], secureOn)
+
+	return payload, nil
+}
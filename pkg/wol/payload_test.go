@@ -0,0 +1,80 @@
+package wol
+
+import (
+	"bytes"
+	"errors"
+	"net"
+	"testing"
+)
+
+func TestNewMagicPacket(t *testing.T) {
+	mac, err := net.ParseMAC("aa:bb:cc:dd:ee:ff")
+	if err != nil {
+		t.Fatalf("failed to parse test MAC address: %s", err)
+	}
+
+	payload, err := NewMagicPacket(mac, nil)
+	if err != nil {
+		t.Fatalf("NewMagicPacket returned an unexpected error: %s", err)
+	}
+
+	if len(payload) != eui48Length+16*eui48Length {
+		t.Fatalf("expected payload length %d, got %d", eui48Length+16*eui48Length, len(payload))
+	}
+
+	for i := 0; i < eui48Length; i++ {
+		if payload[i] != 0xff {
+			t.Fatalf("expected byte %d of the payload to be 0xff, got %#x", i, payload[i])
+		}
+	}
+
+	for repetition := 0; repetition < 16; repetition++ {
+		offset := eui48Length + repetition*eui48Length
+
+		if !bytes.Equal(payload[offset:offset+eui48Length], mac) {
+			t.Fatalf("repetition %d of the MAC address in the payload does not match", repetition)
+		}
+	}
+}
+
+func TestNewMagicPacketRejectsNonEUI48Addresses(t *testing.T) {
+	_, err := NewMagicPacket(net.HardwareAddr{0xaa, 0xbb, 0xcc}, nil)
+	if !errors.Is(err, ErrMACNotEUI48) {
+		t.Fatalf("expected ErrMACNotEUI48, got %v", err)
+	}
+}
+
+func TestNewMagicPacketAppendsSecureOnPassword(t *testing.T) {
+	mac, err := net.ParseMAC("aa:bb:cc:dd:ee:ff")
+	if err != nil {
+		t.Fatalf("failed to parse test MAC address: %s", err)
+	}
+
+	secureOn := []byte{0x11, 0x22, 0x33, 0x44}
+
+	payload, err := NewMagicPacket(mac, secureOn)
+	if err != nil {
+		t.Fatalf("NewMagicPacket returned an unexpected error: %s", err)
+	}
+
+	wantLength := eui48Length + 16*eui48Length + len(secureOn)
+	if len(payload) != wantLength {
+		t.Fatalf("expected payload length %d, got %d", wantLength, len(payload))
+	}
+
+	if !bytes.Equal(payload[len(payload)-len(secureOn):], secureOn) {
+		t.Fatalf("expected payload to end with the SecureOn password %x", secureOn)
+	}
+}
+
+func TestNewMagicPacketRejectsInvalidSecureOnLength(t *testing.T) {
+	mac, err := net.ParseMAC("aa:bb:cc:dd:ee:ff")
+	if err != nil {
+		t.Fatalf("failed to parse test MAC address: %s", err)
+	}
+
+	_, err = NewMagicPacket(mac, []byte{0x01, 0x02, 0x03})
+	if !errors.Is(err, ErrSecureOnPasswordLength) {
+		t.Fatalf("expected ErrSecureOnPasswordLength, got %v", err)
+	}
+}
@@ -0,0 +1,26 @@
+package wol
+
+import "net"
+
+// EtherTypeMagicPacket is the EtherType conventionally used to identify a
+// Wake-on-LAN magic packet sent directly as a layer-2 Ethernet frame,
+// bypassing the UDP/IPv4 stack entirely.
+const EtherTypeMagicPacket uint16 = 0x0842
+
+// broadcastMAC is the layer-2 destination address used to reach every host
+// on the local Ethernet segment.
+var broadcastMAC = net.HardwareAddr{0xff, 0xff, 0xff, 0xff, 0xff, 0xff}
+
+// ethernetFrame wraps payload in an Ethernet II frame addressed to
+// broadcastMAC, with src as the source address and etherType as the
+// EtherType.
+func ethernetFrame(src net.HardwareAddr, etherType uint16, payload []byte) []byte {
+	frame := make([]byte, 0, 2*eui48Length+2+len(payload))
+
+	frame = append(frame, broadcastMAC...)
+	frame = append(frame, src...)
+	frame = append(frame, byte(etherType>>8), byte(etherType&0xff))
+	frame = append(frame, payload...)
+
+	return frame
+}
@@ -0,0 +1,65 @@
+package wol
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net"
+	"syscall"
+)
+
+// rawConn is an AF_PACKET/SOCK_RAW socket bound to a network interface,
+// reused across multiple sendEthernetFrame calls so batch sends don't pay
+// for a socket open/close per target.
+type rawConn struct {
+	fd     int
+	addr   syscall.SockaddrLinklayer
+	srcMAC net.HardwareAddr
+}
+
+// openRawConn opens a raw Ethernet socket on interfaceName, bypassing the
+// kernel IP stack so a target with no IP address configured can still be
+// woken.
+func openRawConn(interfaceName string) (*rawConn, error) {
+	iface, err := net.InterfaceByName(interfaceName)
+	if err != nil {
+		return nil, &OpError{Op: "lookup interface", Net: interfaceName, Err: fmt.Errorf("%w: %v", ErrInterfaceNotFound, err)}
+	}
+
+	fd, err := syscall.Socket(syscall.AF_PACKET, syscall.SOCK_RAW, int(htons(EtherTypeMagicPacket)))
+	if err != nil {
+		return nil, &OpError{Op: "open raw socket", Net: "packet", Err: err}
+	}
+
+	addr := syscall.SockaddrLinklayer{
+		Protocol: htons(EtherTypeMagicPacket),
+		Ifindex:  iface.Index,
+		Halen:    uint8(eui48Length),
+	}
+	copy(addr.Addr[:], broadcastMAC)
+
+	return &rawConn{fd: fd, addr: addr, srcMAC: iface.HardwareAddr}, nil
+}
+
+// sendEthernetFrame sends payload as a raw Ethernet II broadcast frame over
+// c.
+func (c *rawConn) sendEthernetFrame(payload []byte) error {
+	frame := ethernetFrame(c.srcMAC, EtherTypeMagicPacket, payload)
+
+	if err := syscall.Sendto(c.fd, frame, 0, &c.addr); err != nil {
+		return &OpError{Op: "write", Net: "packet", Err: err}
+	}
+
+	return nil
+}
+
+func (c *rawConn) Close() error {
+	return syscall.Close(c.fd)
+}
+
+// htons converts a uint16 from host to network byte order.
+func htons(v uint16) uint16 {
+	buf := make([]byte, 2)
+	binary.BigEndian.PutUint16(buf, v)
+
+	return binary.LittleEndian.Uint16(buf)
+}
@@ -0,0 +1,52 @@
+//go:build !linux
+
+package wol
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/google/gopacket/pcap"
+)
+
+// rawConn is a libpcap handle bound to a network interface, reused across
+// multiple sendEthernetFrame calls so batch sends don't pay for a handle
+// open/close per target.
+type rawConn struct {
+	handle        *pcap.Handle
+	srcMAC        net.HardwareAddr
+	interfaceName string
+}
+
+// openRawConn opens a raw Ethernet handle on interfaceName via libpcap,
+// bypassing the kernel IP stack so a target with no IP address configured
+// can still be woken.
+func openRawConn(interfaceName string) (*rawConn, error) {
+	iface, err := net.InterfaceByName(interfaceName)
+	if err != nil {
+		return nil, &OpError{Op: "lookup interface", Net: interfaceName, Err: fmt.Errorf("%w: %v", ErrInterfaceNotFound, err)}
+	}
+
+	handle, err := pcap.OpenLive(interfaceName, 1<<16, false, pcap.BlockForever)
+	if err != nil {
+		return nil, &OpError{Op: "open pcap handle", Net: interfaceName, Err: err}
+	}
+
+	return &rawConn{handle: handle, srcMAC: iface.HardwareAddr, interfaceName: interfaceName}, nil
+}
+
+// sendEthernetFrame sends payload as a raw Ethernet II broadcast frame over
+// c.
+func (c *rawConn) sendEthernetFrame(payload []byte) error {
+	if err := c.handle.WritePacketData(ethernetFrame(c.srcMAC, EtherTypeMagicPacket, payload)); err != nil {
+		return &OpError{Op: "write", Net: c.interfaceName, Err: err}
+	}
+
+	return nil
+}
+
+func (c *rawConn) Close() error {
+	c.handle.Close()
+
+	return nil
+}
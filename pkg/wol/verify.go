@@ -0,0 +1,134 @@
+package wol
+
+import (
+	"errors"
+	"net"
+	"time"
+)
+
+// VerifyMethod selects how WakeAndVerify confirms a target came back up.
+type VerifyMethod int
+
+const (
+	// VerifyNone disables post-wake verification. WakeAndVerify behaves
+	// like Wake.
+	VerifyNone VerifyMethod = iota
+
+	// VerifyTCP confirms the target is up by connecting to a TCP port.
+	VerifyTCP
+
+	// VerifyICMP confirms the target is up by sending an ICMP echo
+	// request and waiting for a reply.
+	VerifyICMP
+
+	// VerifyARP confirms the target is up by sending an ARP request and
+	// waiting for a reply. It is particularly useful because it can
+	// succeed before the target's OS starts answering on higher layers.
+	VerifyARP
+)
+
+// ErrUnsupportedVerifyMethod is returned when a VerifyMethod has no probe
+// implementation on the current platform.
+var ErrUnsupportedVerifyMethod = errors.New("unsupported verify method")
+
+// VerifyOptions configures post-wake reachability verification performed by
+// Client.WakeAndVerify.
+type VerifyOptions struct {
+	// Method selects the probe used to confirm the target is reachable.
+	Method VerifyMethod
+
+	// Target is the address probed: host:port for VerifyTCP, a host or IP
+	// for VerifyICMP, and an IPv4 address for VerifyARP.
+	Target string
+
+	// Timeout bounds how long WakeAndVerify waits, across all probe
+	// attempts within one magic-packet transmission, for the target to
+	// respond.
+	Timeout time.Duration
+
+	// Interval is the delay between probe attempts.
+	Interval time.Duration
+
+	// Retries is how many additional times the magic packet is
+	// retransmitted if the target still hasn't responded after Timeout.
+	Retries int
+}
+
+// VerifyResult reports the outcome of a WakeAndVerify call.
+type VerifyResult struct {
+	Reachable     bool          `json:"reachable"`
+	Attempts      int           `json:"attempts"`
+	FirstResponse time.Duration `json:"first_response,omitempty"`
+}
+
+// WakeAndVerify sends a Wake-on-LAN magic packet to target, as Wake does,
+// then probes it using opts to confirm it actually came up, retransmitting
+// the magic packet up to opts.Retries times if the probe keeps failing.
+func (c *Client) WakeAndVerify(target net.HardwareAddr, opts VerifyOptions) (VerifyResult, error) {
+	if err := c.Wake(target); err != nil {
+		return VerifyResult{}, err
+	}
+
+	if opts.Method == VerifyNone {
+		return VerifyResult{}, nil
+	}
+
+	start := time.Now()
+	result := VerifyResult{}
+
+	for retry := 0; ; retry++ {
+		deadline := time.Now().Add(opts.Timeout)
+
+		for time.Now().Before(deadline) {
+			result.Attempts++
+
+			ok, err := c.probe(opts)
+			if err != nil {
+				return result, err
+			}
+
+			if ok {
+				result.Reachable = true
+				result.FirstResponse = time.Since(start)
+
+				return result, nil
+			}
+
+			time.Sleep(opts.Interval)
+		}
+
+		if retry >= opts.Retries {
+			return result, nil
+		}
+
+		if err := c.Wake(target); err != nil {
+			return result, err
+		}
+	}
+}
+
+func (c *Client) probe(opts VerifyOptions) (bool, error) {
+	switch opts.Method {
+	case VerifyTCP:
+		return probeTCP(opts.Target, opts.Interval)
+
+	case VerifyICMP:
+		return probeICMP(opts.Target, opts.Interval)
+
+	case VerifyARP:
+		return c.probeARP(opts.Target, opts.Interval)
+
+	default:
+		return false, ErrUnsupportedVerifyMethod
+	}
+}
+
+func probeTCP(target string, timeout time.Duration) (bool, error) {
+	conn, err := net.DialTimeout("tcp", target, timeout)
+	if err != nil {
+		return false, nil
+	}
+	defer conn.Close()
+
+	return true, nil
+}
@@ -0,0 +1,49 @@
+package wol
+
+import (
+	"net"
+	"testing"
+	"time"
+)
+
+func TestProbeTCPReachable(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to start test listener: %s", err)
+	}
+	defer listener.Close()
+
+	go func() {
+		conn, err := listener.Accept()
+		if err == nil {
+			conn.Close()
+		}
+	}()
+
+	ok, err := probeTCP(listener.Addr().String(), time.Second)
+	if err != nil {
+		t.Fatalf("probeTCP returned an unexpected error: %s", err)
+	}
+
+	if !ok {
+		t.Fatal("expected probeTCP to report the listener as reachable")
+	}
+}
+
+func TestProbeTCPUnreachable(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to find a free port: %s", err)
+	}
+	addr := listener.Addr().String()
+	listener.Close()
+
+	ok, err := probeTCP(addr, 200*time.Millisecond)
+	if err != nil {
+		t.Fatalf("probeTCP returned an unexpected error: %s", err)
+	}
+
+	if ok {
+		t.Fatal("expected probeTCP to report the closed port as unreachable")
+	}
+}
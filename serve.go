@@ -0,0 +1,48 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+)
+
+// writeTimeout bounds how long a single request may take to handle,
+// including the magic-packet retransmissions and reachability probes a
+// /wake request with -verify can trigger.
+const writeTimeout = (maxRetries + 1) * (defaultVerifyTimeout + 2*defaultVerifyInterval)
+
+// runServeCommand runs the `serve` subcommand: a long-lived HTTP/JSON API
+// that wakes hosts listed in a YAML configuration file on behalf of LAN
+// clients, so the tool can run unattended on a small always-on box.
+func runServeCommand(args []string) {
+	serveFlags := flag.NewFlagSet("serve", flag.ExitOnError)
+	configPath := serveFlags.String("config", "hosts.yaml", "`path` to the YAML hosts configuration file")
+	listenAddress := serveFlags.String("addr", ":8080", "`address` the HTTP API listens on")
+
+	serveFlags.Parse(args)
+
+	hosts, err := loadHostsConfig(*configPath)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "The following error occurred when loading the hosts configuration: "+err.Error())
+
+		os.Exit(hostsConfigLoadingError)
+	}
+
+	fmt.Printf("Listening on %s, serving %d configured host(s).\n", *listenAddress, len(hosts))
+
+	httpServer := &http.Server{
+		Addr:              *listenAddress,
+		Handler:           newServer(hosts),
+		ReadHeaderTimeout: 5 * time.Second,
+		ReadTimeout:       5 * time.Second,
+		WriteTimeout:      writeTimeout,
+	}
+
+	if err := httpServer.ListenAndServe(); err != nil {
+		fmt.Fprintln(os.Stderr, "The following error occurred when running the HTTP server: "+err.Error())
+
+		os.Exit(httpServerError)
+	}
+}
@@ -0,0 +1,177 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/tylerRosso/wake-on-lan/pkg/wol"
+)
+
+// Timing used to verify a host's reachability when a request or its host
+// configuration requests verification; neither can override it.
+const (
+	defaultVerifyTimeout  = 5 * time.Second
+	defaultVerifyInterval = time.Second
+)
+
+// maxRetries bounds how many times a /wake request may ask the server to
+// retransmit the magic packet. Without a cap, a client-supplied retries
+// count would pin the handler goroutine for retries*defaultVerifyTimeout,
+// an easy remote denial of service.
+const maxRetries = 10
+
+// server is the HTTP handler backing the `serve` subcommand's JSON API.
+type server struct {
+	hosts map[string]HostConfig
+	mux   *http.ServeMux
+}
+
+// newServer builds a server serving the given configured hosts.
+func newServer(hosts []HostConfig) *server {
+	byName := make(map[string]HostConfig, len(hosts))
+	for _, host := range hosts {
+		byName[host.Name] = host
+	}
+
+	s := &server{hosts: byName, mux: http.NewServeMux()}
+	s.mux.HandleFunc("/wake", s.handleWake)
+	s.mux.HandleFunc("/hosts", s.handleHosts)
+
+	return s
+}
+
+func (s *server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+// wakeRequest is the JSON body accepted by POST /wake. A request either
+// names a configured host or supplies the MAC address (and optionally the
+// other fields) directly.
+type wakeRequest struct {
+	Host          string `json:"host"`
+	MACAddress    string `json:"mac"`
+	InterfaceName string `json:"interface"`
+	Broadcast     string `json:"broadcast"`
+	SecureOn      string `json:"secureon"`
+	Verify        string `json:"verify"`
+	Retries       int    `json:"retries"`
+}
+
+func (s *server) handleWake(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	var req wakeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "could not parse request body: "+err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	if req.Host != "" {
+		host, ok := s.hosts[req.Host]
+		if !ok {
+			http.Error(w, fmt.Sprintf("unknown host %q", req.Host), http.StatusNotFound)
+
+			return
+		}
+
+		req.MACAddress = host.MACAddress
+		if req.InterfaceName == "" {
+			req.InterfaceName = host.InterfaceName
+		}
+		if req.Broadcast == "" {
+			req.Broadcast = host.Broadcast
+		}
+		if req.SecureOn == "" {
+			req.SecureOn = host.SecureOn
+		}
+		if req.Verify == "" {
+			req.Verify = host.Verify
+		}
+	}
+
+	mac, err := net.ParseMAC(req.MACAddress)
+	if err != nil {
+		http.Error(w, "invalid MAC address: "+err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	secureOn, err := parseSecureOnPassword(req.SecureOn)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	client := wol.NewClient(req.InterfaceName)
+	client.SecureOn = secureOn
+	client.Broadcast = req.Broadcast
+
+	if req.Verify == "" {
+		if err := client.Wake(mac); err != nil {
+			http.Error(w, "failed to send Wake-on-LAN payload: "+err.Error(), http.StatusInternalServerError)
+
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+
+		return
+	}
+
+	method, target, err := parseVerifySpec(req.Verify)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	if req.Retries < 0 || req.Retries > maxRetries {
+		http.Error(w, fmt.Sprintf("retries must be between 0 and %d", maxRetries), http.StatusBadRequest)
+
+		return
+	}
+
+	result, err := client.WakeAndVerify(mac, wol.VerifyOptions{
+		Method:   method,
+		Target:   target,
+		Timeout:  defaultVerifyTimeout,
+		Interval: defaultVerifyInterval,
+		Retries:  req.Retries,
+	})
+	if err != nil {
+		http.Error(w, "failed to send Wake-on-LAN payload: "+err.Error(), http.StatusInternalServerError)
+
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
+
+func (s *server) handleHosts(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	hosts := make([]HostConfig, 0, len(s.hosts))
+	for _, host := range s.hosts {
+		hosts = append(hosts, host)
+	}
+
+	sort.Slice(hosts, func(i, j int) bool { return hosts[i].Name < hosts[j].Name })
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(hosts)
+}